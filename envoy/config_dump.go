@@ -0,0 +1,235 @@
+// Package envoy parses the JSON produced by an Envoy sidecar's /config_dump admin
+// endpoint and correlates it against the Istio config (VirtualServices,
+// DestinationRules, RouteRules) that is supposed to be driving it. It backs the
+// ServiceDescribe handler, which wants to answer "what is actually applied to this
+// pod" rather than "what objects exist in the namespace".
+package envoy
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// ConfigDump is the top-level shape of Envoy's /config_dump response: a list of
+// sections, each tagged by @type, that we decode lazily depending on what we need.
+type ConfigDump struct {
+	Configs []json.RawMessage `json:"configs"`
+}
+
+// section is used to peek at the @type discriminator of each entry in Configs before
+// deciding which concrete type to unmarshal it into.
+type section struct {
+	Type string `json:"@type"`
+}
+
+// ListenersConfigDump is the "type.googleapis.com/envoy.admin.v2alpha.ListenersConfigDump" section.
+type ListenersConfigDump struct {
+	DynamicListeners []struct {
+		ActiveState *struct {
+			Listener Listener `json:"listener"`
+		} `json:"active_state"`
+	} `json:"dynamic_listeners"`
+	StaticListeners []struct {
+		Listener Listener `json:"listener"`
+	} `json:"static_listeners"`
+}
+
+// Listener is a trimmed-down envoy.api.v2.Listener: only the fields ServiceDescribe
+// needs to walk Listener -> FilterChain -> HttpConnectionManager -> RouteConfig.
+type Listener struct {
+	Name         string        `json:"name"`
+	FilterChains []FilterChain `json:"filter_chains"`
+}
+
+// FilterChain carries the per-chain TransportSocket, whose presence (and SNI config)
+// is how we infer whether mTLS is in play for this listener.
+type FilterChain struct {
+	FilterChainMatch *struct {
+		ServerNames []string `json:"server_names"`
+	} `json:"filter_chain_match"`
+	TransportSocket *struct {
+		Name string `json:"name"`
+	} `json:"transport_socket"`
+	Filters []Filter `json:"filters"`
+}
+
+// Filter is a network filter entry. We only decode TypedConfig when Name matches a
+// filter we understand (http_connection_manager today).
+type Filter struct {
+	Name        string          `json:"name"`
+	TypedConfig json.RawMessage `json:"typed_config"`
+}
+
+// HTTPConnectionManager is the typed_config of an envoy.filters.network.http_connection_manager filter.
+type HTTPConnectionManager struct {
+	RouteConfig *RouteConfiguration `json:"route_config"`
+	Rds         *struct {
+		RouteConfigName string `json:"route_config_name"`
+	} `json:"rds"`
+	HTTPFilters []HTTPFilter `json:"http_filters"`
+}
+
+// HTTPFilter is an HTTP filter entry of the HttpConnectionManager, e.g. the RBAC
+// filter we extract authorization policies from.
+type HTTPFilter struct {
+	Name        string          `json:"name"`
+	TypedConfig json.RawMessage `json:"typed_config"`
+}
+
+// RBAC is the typed_config of an envoy.filters.http.rbac filter.
+type RBAC struct {
+	Rules *struct {
+		Policies map[string]struct {
+			Permissions []json.RawMessage `json:"permissions"`
+			Principals  []json.RawMessage `json:"principals"`
+		} `json:"policies"`
+	} `json:"rules"`
+}
+
+// RouteConfiguration is an envoy.api.v2.RouteConfiguration, reached either inline
+// (RouteConfig) or by name through RDS (looked up in RouteConfigsConfigDump).
+type RouteConfiguration struct {
+	Name         string        `json:"name"`
+	VirtualHosts []VirtualHost `json:"virtual_hosts"`
+}
+
+// RouteConfigsConfigDump is the "type.googleapis.com/envoy.admin.v2alpha.RoutesConfigDump" section.
+type RouteConfigsConfigDump struct {
+	DynamicRouteConfigs []struct {
+		RouteConfig RouteConfiguration `json:"route_config"`
+	} `json:"dynamic_route_configs"`
+	StaticRouteConfigs []struct {
+		RouteConfig RouteConfiguration `json:"route_config"`
+	} `json:"static_route_configs"`
+}
+
+// VirtualHost is an envoy.api.v2.route.VirtualHost.
+type VirtualHost struct {
+	Name    string   `json:"name"`
+	Domains []string `json:"domains"`
+	Routes  []Route  `json:"routes"`
+}
+
+// Route is an envoy.api.v2.route.Route; we only care about where it routes to.
+type Route struct {
+	Match struct {
+		Prefix string `json:"prefix"`
+	} `json:"match"`
+	Route struct {
+		Cluster string `json:"cluster"`
+	} `json:"route"`
+}
+
+// ClustersConfigDump is the "type.googleapis.com/envoy.admin.v2alpha.ClustersConfigDump" section.
+type ClustersConfigDump struct {
+	DynamicActiveClusters []struct {
+		Cluster Cluster `json:"cluster"`
+	} `json:"dynamic_active_clusters"`
+	StaticClusters []struct {
+		Cluster Cluster `json:"cluster"`
+	} `json:"static_clusters"`
+}
+
+// Cluster is an envoy.api.v2.Cluster; only the name matters here, since it is what we
+// parse with ParseClusterName to recover the DestinationRule subset it came from.
+type Cluster struct {
+	Name string `json:"name"`
+}
+
+// Decode splits raw into its typed sections. Unknown sections are ignored: a config
+// dump from a newer/older Envoy than we were written against should degrade to
+// "missing data" rather than fail outright.
+func Decode(raw []byte) (*ParsedConfigDump, error) {
+	var dump ConfigDump
+	if err := json.Unmarshal(raw, &dump); err != nil {
+		return nil, fmt.Errorf("envoy: invalid config_dump: %v", err)
+	}
+
+	parsed := &ParsedConfigDump{}
+	for _, raw := range dump.Configs {
+		var sec section
+		if err := json.Unmarshal(raw, &sec); err != nil {
+			continue
+		}
+		switch {
+		case strings.HasSuffix(sec.Type, "ListenersConfigDump"):
+			var listeners ListenersConfigDump
+			if err := json.Unmarshal(raw, &listeners); err == nil {
+				for _, d := range listeners.DynamicListeners {
+					if d.ActiveState != nil {
+						parsed.Listeners = append(parsed.Listeners, d.ActiveState.Listener)
+					}
+				}
+				for _, s := range listeners.StaticListeners {
+					parsed.Listeners = append(parsed.Listeners, s.Listener)
+				}
+			}
+		case strings.HasSuffix(sec.Type, "ClustersConfigDump"):
+			var clusters ClustersConfigDump
+			if err := json.Unmarshal(raw, &clusters); err == nil {
+				for _, d := range clusters.DynamicActiveClusters {
+					parsed.Clusters = append(parsed.Clusters, d.Cluster)
+				}
+				for _, s := range clusters.StaticClusters {
+					parsed.Clusters = append(parsed.Clusters, s.Cluster)
+				}
+			}
+		case strings.HasSuffix(sec.Type, "RoutesConfigDump"):
+			var routes RouteConfigsConfigDump
+			if err := json.Unmarshal(raw, &routes); err == nil {
+				for _, d := range routes.DynamicRouteConfigs {
+					parsed.RouteConfigs = append(parsed.RouteConfigs, d.RouteConfig)
+				}
+				for _, s := range routes.StaticRouteConfigs {
+					parsed.RouteConfigs = append(parsed.RouteConfigs, s.RouteConfig)
+				}
+			}
+		}
+	}
+	return parsed, nil
+}
+
+// ParsedConfigDump is the flattened, section-agnostic view of a config dump that the
+// report builder walks.
+type ParsedConfigDump struct {
+	Listeners    []Listener
+	RouteConfigs []RouteConfiguration
+	Clusters     []Cluster
+}
+
+// RouteConfigByName returns the RouteConfiguration named name, as referenced by an
+// HttpConnectionManager's Rds.RouteConfigName.
+func (p *ParsedConfigDump) RouteConfigByName(name string) (RouteConfiguration, bool) {
+	for _, rc := range p.RouteConfigs {
+		if rc.Name == name {
+			return rc, true
+		}
+	}
+	return RouteConfiguration{}, false
+}
+
+// ClusterName identifies the pieces of Istio's `outbound|port|subset|host` (or
+// `inbound|...`) cluster naming convention.
+type ClusterName struct {
+	Direction string
+	Port      string
+	Subset    string
+	Host      string
+}
+
+// ParseClusterName splits an Envoy cluster name built by Istio's naming convention,
+// e.g. "outbound|9080|v2|reviews.bookinfo.svc.cluster.local". Clusters that don't
+// follow the convention (e.g. "BlackHoleCluster", "PassthroughCluster") return ok=false.
+func ParseClusterName(name string) (cn ClusterName, ok bool) {
+	parts := strings.Split(name, "|")
+	if len(parts) != 4 {
+		return ClusterName{}, false
+	}
+	return ClusterName{
+		Direction: parts[0],
+		Port:      parts[1],
+		Subset:    parts[2],
+		Host:      parts[3],
+	}, true
+}
@@ -0,0 +1,33 @@
+package envoy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseClusterName(t *testing.T) {
+	assert := assert.New(t)
+
+	cn, ok := ParseClusterName("outbound|9080|v2|reviews.bookinfo.svc.cluster.local")
+	assert.True(ok)
+	assert.Equal("outbound", cn.Direction)
+	assert.Equal("9080", cn.Port)
+	assert.Equal("v2", cn.Subset)
+	assert.Equal("reviews.bookinfo.svc.cluster.local", cn.Host)
+}
+
+func TestParseClusterNameNoSubset(t *testing.T) {
+	assert := assert.New(t)
+
+	cn, ok := ParseClusterName("inbound|9080||reviews.bookinfo.svc.cluster.local")
+	assert.True(ok)
+	assert.Equal("", cn.Subset)
+}
+
+func TestParseClusterNameNotConventional(t *testing.T) {
+	assert := assert.New(t)
+
+	_, ok := ParseClusterName("BlackHoleCluster")
+	assert.False(ok)
+}
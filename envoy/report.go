@@ -0,0 +1,305 @@
+package envoy
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+const (
+	httpConnectionManager = "envoy.http_connection_manager"
+	rbacFilter            = "envoy.filters.http.rbac"
+)
+
+// MTLS modes a PodReport can report for a listener, mirroring Istio's own vocabulary.
+const (
+	MTLSStrict     = "STRICT"
+	MTLSPermissive = "PERMISSIVE"
+	MTLSNone       = "NONE"
+)
+
+// MatchedObject is an Istio config object (RouteRule, VirtualService, or
+// DestinationRule) that PodReport found was actually wired into the Envoy config it
+// was built from.
+type MatchedObject struct {
+	Name   string `json:"name"`
+	Host   string `json:"host"`
+	Subset string `json:"subset,omitempty"`
+}
+
+// OrphanObject is an Istio config object that targets this pod's namespace but that
+// no listener/route/cluster in the config dump actually references.
+type OrphanObject struct {
+	ObjectType string `json:"objectType"`
+	Name       string `json:"name"`
+}
+
+// PodReport is the per-pod result of correlating one Envoy config dump against the
+// Istio config that is supposed to be driving it.
+type PodReport struct {
+	Pod              string          `json:"pod"`
+	RouteRules       []MatchedObject `json:"routeRules"`
+	VirtualServices  []MatchedObject `json:"virtualServices"`
+	DestinationRules []MatchedObject `json:"destinationRules"`
+	MTLS             string          `json:"mtls"`
+	Authorization    []string        `json:"authorizationPolicies"`
+	Orphan           []OrphanObject  `json:"orphanConfig"`
+	Warnings         []string        `json:"warnings,omitempty"`
+}
+
+// BuildReport walks a pod's parsed config dump and correlates it against details,
+// the VirtualServices/DestinationRules/RouteRules the business layer found for this
+// service, producing the structured per-pod report ServiceDescribe responds with.
+func BuildReport(pod string, parsed *ParsedConfigDump, details *kubernetes.IstioDetails) *PodReport {
+	report := &PodReport{Pod: pod, MTLS: MTLSNone}
+
+	matchedRR := map[string]bool{}
+	matchedVS := map[string]bool{}
+	matchedDR := map[string]bool{}
+	vsHosts := map[string]bool{}
+
+	hasTLS, hasPlaintext := false, false
+
+	for _, listener := range parsed.Listeners {
+		// mTLS is a property of the pod's inbound traffic, not its outbound calls to
+		// other services: every sidecar also has an outbound-capture listener whose
+		// chains never carry a TransportSocket, which would otherwise always read as
+		// "plaintext" and mask a STRICT inbound PeerAuthentication as PERMISSIVE.
+		if isInboundListener(listener) {
+			for _, chain := range listener.FilterChains {
+				if isTLSChain(chain) {
+					hasTLS = true
+				} else {
+					hasPlaintext = true
+				}
+			}
+		}
+
+		for _, chain := range listener.FilterChains {
+			for _, filter := range chain.Filters {
+				if filter.Name != httpConnectionManager {
+					continue
+				}
+				var hcm HTTPConnectionManager
+				if err := json.Unmarshal(filter.TypedConfig, &hcm); err != nil {
+					continue
+				}
+
+				routeConfig, ok := resolveRouteConfig(hcm, parsed)
+				if !ok {
+					continue
+				}
+
+				for _, filt := range hcm.HTTPFilters {
+					if filt.Name != rbacFilter {
+						continue
+					}
+					var rbac RBAC
+					if err := json.Unmarshal(filt.TypedConfig, &rbac); err == nil && rbac.Rules != nil {
+						for policy := range rbac.Rules.Policies {
+							report.Authorization = append(report.Authorization, policy)
+						}
+					}
+				}
+
+				for _, vh := range routeConfig.VirtualHosts {
+					for _, host := range vh.Domains {
+						host = strings.TrimSuffix(host, ":*")
+						if vs, ok := findVirtualServiceByHost(details, host); ok {
+							name := vs.GetObjectMeta().Name
+							if !matchedVS[name] {
+								matchedVS[name] = true
+								report.VirtualServices = append(report.VirtualServices, MatchedObject{Name: name, Host: host})
+							}
+							vsHosts[host] = true
+						}
+						if rr, ok := findRouteRuleByHost(details, host); ok {
+							name := rr.GetObjectMeta().Name
+							if !matchedRR[name] {
+								matchedRR[name] = true
+								report.RouteRules = append(report.RouteRules, MatchedObject{Name: name, Host: host})
+							}
+						}
+					}
+
+					for _, route := range vh.Routes {
+						cn, ok := ParseClusterName(route.Route.Cluster)
+						if !ok {
+							continue
+						}
+						if dr, subset, ok := findDestinationRuleByHostAndSubset(details, cn.Host, cn.Subset); ok {
+							name := dr.GetObjectMeta().Name
+							key := name + "/" + subset
+							if !matchedDR[key] {
+								matchedDR[key] = true
+								report.DestinationRules = append(report.DestinationRules, MatchedObject{Name: name, Host: cn.Host, Subset: subset})
+							}
+						}
+					}
+				}
+			}
+		}
+	}
+
+	switch {
+	case hasTLS && hasPlaintext:
+		report.MTLS = MTLSPermissive
+	case hasTLS:
+		report.MTLS = MTLSStrict
+	default:
+		report.MTLS = MTLSNone
+	}
+
+	for host := range vsHosts {
+		claimants := 0
+		for _, vs := range details.VirtualServices {
+			for _, h := range stringSliceSpec(vs, "hosts") {
+				if h == host {
+					claimants++
+					break
+				}
+			}
+		}
+		if claimants > 1 {
+			report.Warnings = append(report.Warnings, "multiple VirtualServices match host "+host)
+		}
+	}
+
+	if details != nil {
+		for _, rr := range details.RouteRules {
+			if !matchedRR[rr.GetObjectMeta().Name] {
+				report.Orphan = append(report.Orphan, OrphanObject{ObjectType: "routerule", Name: rr.GetObjectMeta().Name})
+			}
+		}
+		for _, vs := range details.VirtualServices {
+			if !matchedVS[vs.GetObjectMeta().Name] {
+				report.Orphan = append(report.Orphan, OrphanObject{ObjectType: "virtualservice", Name: vs.GetObjectMeta().Name})
+			}
+		}
+		for _, dr := range details.DestinationRules {
+			name := dr.GetObjectMeta().Name
+			referenced := false
+			for key := range matchedDR {
+				if strings.HasPrefix(key, name+"/") {
+					referenced = true
+					break
+				}
+			}
+			if !referenced {
+				report.Orphan = append(report.Orphan, OrphanObject{ObjectType: "destinationrule", Name: name})
+			}
+		}
+	}
+
+	return report
+}
+
+func resolveRouteConfig(hcm HTTPConnectionManager, parsed *ParsedConfigDump) (RouteConfiguration, bool) {
+	if hcm.RouteConfig != nil {
+		return *hcm.RouteConfig, true
+	}
+	if hcm.Rds != nil {
+		return parsed.RouteConfigByName(hcm.Rds.RouteConfigName)
+	}
+	return RouteConfiguration{}, false
+}
+
+// isInboundListener reports whether listener terminates traffic arriving at the pod,
+// as opposed to capturing the pod's own outbound calls to other services. Inbound
+// listeners follow Istio's "inbound|port|subset|host" naming convention, the same one
+// ParseClusterName parses for clusters.
+func isInboundListener(listener Listener) bool {
+	return strings.HasPrefix(listener.Name, "inbound|") || strings.HasPrefix(listener.Name, "virtualInbound")
+}
+
+// isTLSChain reports whether chain terminates mTLS, from TransportSocket presence and
+// SNI: a chain with FilterChainMatch.ServerNames set is routed by SNI, which only
+// happens for a TLS-terminating chain even when TransportSocket itself wasn't decoded.
+func isTLSChain(chain FilterChain) bool {
+	if chain.TransportSocket != nil {
+		return true
+	}
+	return chain.FilterChainMatch != nil && len(chain.FilterChainMatch.ServerNames) > 0
+}
+
+// findRouteRuleByHost looks up a RouteRule (the config.istio.io/v1alpha2 predecessor
+// to VirtualService) whose spec.destination.name matches host, either as a short
+// in-mesh service name or the full FQDN Envoy reports.
+func findRouteRuleByHost(details *kubernetes.IstioDetails, host string) (kubernetes.IstioObject, bool) {
+	if details == nil {
+		return nil, false
+	}
+	shortName := strings.SplitN(host, ".", 2)[0]
+	for _, rr := range details.RouteRules {
+		dest, _ := rr.GetSpec()["destination"].(map[string]interface{})
+		name, _ := dest["name"].(string)
+		if name != "" && (name == host || name == shortName) {
+			return rr, true
+		}
+	}
+	return nil, false
+}
+
+func findVirtualServiceByHost(details *kubernetes.IstioDetails, host string) (kubernetes.IstioObject, bool) {
+	if details == nil {
+		return nil, false
+	}
+	for _, vs := range details.VirtualServices {
+		for _, h := range stringSliceSpec(vs, "hosts") {
+			if h == host {
+				return vs, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func findDestinationRuleByHostAndSubset(details *kubernetes.IstioDetails, host string, subset string) (kubernetes.IstioObject, string, bool) {
+	if details == nil {
+		return nil, "", false
+	}
+	for _, dr := range details.DestinationRules {
+		if stringSpec(dr, "host") != host {
+			continue
+		}
+		if subset == "" {
+			return dr, "", true
+		}
+		for _, raw := range sliceSpec(dr, "subsets") {
+			if m, ok := raw.(map[string]interface{}); ok {
+				if name, _ := m["name"].(string); name == subset {
+					return dr, subset, true
+				}
+			}
+		}
+	}
+	return nil, "", false
+}
+
+func stringSpec(obj kubernetes.IstioObject, key string) string {
+	if obj == nil {
+		return ""
+	}
+	s, _ := obj.GetSpec()[key].(string)
+	return s
+}
+
+func sliceSpec(obj kubernetes.IstioObject, key string) []interface{} {
+	if obj == nil {
+		return nil
+	}
+	s, _ := obj.GetSpec()[key].([]interface{})
+	return s
+}
+
+func stringSliceSpec(obj kubernetes.IstioObject, key string) []string {
+	raw := sliceSpec(obj, key)
+	out := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
@@ -0,0 +1,167 @@
+package envoy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// synthetic config dump for a "reviews" pod: one STRICT-mTLS inbound listener, one
+// outbound-capture listener with a plaintext chain (present on every sidecar, and
+// must not be mistaken for the pod's own inbound posture), and one outbound listener
+// whose HttpConnectionManager routes "reviews.bookinfo.svc.cluster.local" to the v2
+// subset, guarded by an RBAC policy.
+const reviewsConfigDump = `{
+	"configs": [
+		{
+			"@type": "type.googleapis.com/envoy.admin.v2alpha.ListenersConfigDump",
+			"static_listeners": [
+				{
+					"listener": {
+						"name": "inbound|9080||reviews.bookinfo.svc.cluster.local",
+						"filter_chains": [
+							{ "transport_socket": {"name": "envoy.transport_sockets.tls"}, "filters": [] }
+						]
+					}
+				},
+				{
+					"listener": {
+						"name": "virtualOutbound",
+						"filter_chains": [
+							{ "filters": [] }
+						]
+					}
+				},
+				{
+					"listener": {
+						"name": "0.0.0.0_9080",
+						"filter_chains": [
+							{
+								"filters": [
+									{
+										"name": "envoy.http_connection_manager",
+										"typed_config": {
+											"route_config": {
+												"virtual_hosts": [
+													{
+														"domains": ["reviews.bookinfo.svc.cluster.local"],
+														"routes": [
+															{ "route": {"cluster": "outbound|9080|v2|reviews.bookinfo.svc.cluster.local"} }
+														]
+													}
+												]
+											},
+											"http_filters": [
+												{
+													"name": "envoy.filters.http.rbac",
+													"typed_config": {
+														"rules": {"policies": {"reviews-viewer": {}}}
+													}
+												}
+											]
+										}
+									}
+								]
+							}
+						]
+					}
+				}
+			]
+		}
+	]
+}`
+
+func genericIstioObject(name string, spec map[string]interface{}) kubernetes.IstioObject {
+	return &kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name},
+		Spec:       spec,
+	}
+}
+
+func reviewsDetails() *kubernetes.IstioDetails {
+	return &kubernetes.IstioDetails{
+		RouteRules: []kubernetes.IstioObject{
+			genericIstioObject("reviews-rr", map[string]interface{}{
+				"destination": map[string]interface{}{"name": "reviews"},
+			}),
+			genericIstioObject("legacy-rule", map[string]interface{}{
+				"destination": map[string]interface{}{"name": "something-else"},
+			}),
+		},
+		VirtualServices: []kubernetes.IstioObject{
+			genericIstioObject("reviews-vs", map[string]interface{}{
+				"hosts": []interface{}{"reviews.bookinfo.svc.cluster.local"},
+			}),
+			genericIstioObject("reviews-vs-dup", map[string]interface{}{
+				"hosts": []interface{}{"reviews.bookinfo.svc.cluster.local"},
+			}),
+			genericIstioObject("orphan-vs", map[string]interface{}{
+				"hosts": []interface{}{"other.bookinfo.svc.cluster.local"},
+			}),
+		},
+		DestinationRules: []kubernetes.IstioObject{
+			genericIstioObject("reviews-dr", map[string]interface{}{
+				"host":    "reviews.bookinfo.svc.cluster.local",
+				"subsets": []interface{}{map[string]interface{}{"name": "v2"}},
+			}),
+			genericIstioObject("orphan-dr", map[string]interface{}{
+				"host": "other.bookinfo.svc.cluster.local",
+			}),
+		},
+	}
+}
+
+func TestBuildReportScopesMTLSToInboundListener(t *testing.T) {
+	assert := assert.New(t)
+
+	parsed, err := Decode([]byte(reviewsConfigDump))
+	assert.NoError(err)
+
+	report := BuildReport("reviews-v2-12345", parsed, reviewsDetails())
+
+	// The outbound-capture listener's plaintext chain must not drag a STRICT inbound
+	// listener down to PERMISSIVE.
+	assert.Equal(MTLSStrict, report.MTLS)
+}
+
+func TestBuildReportMatchesRouteRuleVirtualServiceAndDestinationRule(t *testing.T) {
+	assert := assert.New(t)
+
+	parsed, err := Decode([]byte(reviewsConfigDump))
+	assert.NoError(err)
+
+	report := BuildReport("reviews-v2-12345", parsed, reviewsDetails())
+
+	assert.Contains(report.RouteRules, MatchedObject{Name: "reviews-rr", Host: "reviews.bookinfo.svc.cluster.local"})
+	assert.Contains(report.VirtualServices, MatchedObject{Name: "reviews-vs", Host: "reviews.bookinfo.svc.cluster.local"})
+	assert.Contains(report.DestinationRules, MatchedObject{Name: "reviews-dr", Host: "reviews.bookinfo.svc.cluster.local", Subset: "v2"})
+	assert.Contains(report.Authorization, "reviews-viewer")
+}
+
+func TestBuildReportWarnsOnAmbiguousVirtualServiceMatch(t *testing.T) {
+	assert := assert.New(t)
+
+	parsed, err := Decode([]byte(reviewsConfigDump))
+	assert.NoError(err)
+
+	report := BuildReport("reviews-v2-12345", parsed, reviewsDetails())
+
+	// reviews-vs and reviews-vs-dup both claim the same host.
+	assert.Contains(report.Warnings, "multiple VirtualServices match host reviews.bookinfo.svc.cluster.local")
+}
+
+func TestBuildReportListsOrphanConfig(t *testing.T) {
+	assert := assert.New(t)
+
+	parsed, err := Decode([]byte(reviewsConfigDump))
+	assert.NoError(err)
+
+	report := BuildReport("reviews-v2-12345", parsed, reviewsDetails())
+
+	assert.Contains(report.Orphan, OrphanObject{ObjectType: "routerule", Name: "legacy-rule"})
+	assert.Contains(report.Orphan, OrphanObject{ObjectType: "virtualservice", Name: "orphan-vs"})
+	assert.Contains(report.Orphan, OrphanObject{ObjectType: "destinationrule", Name: "orphan-dr"})
+}
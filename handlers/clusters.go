@@ -0,0 +1,26 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// ClusterInfo is the representation of a single registered cluster returned by
+// ClustersList.
+type ClusterInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// ClustersList method returns every cluster Kiali can currently reach: the local
+// cluster plus any remote cluster registered through a multi-cluster kubeconfig
+// Secret.
+func ClustersList(w http.ResponseWriter, r *http.Request) {
+	clusters := []ClusterInfo{{Name: kubernetes.LocalCluster}}
+	for _, info := range kubernetes.Clusters.List() {
+		clusters = append(clusters, ClusterInfo{Name: info.Name, Version: info.Version})
+	}
+
+	RespondWithJSON(w, http.StatusOK, clusters)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"k8s.io/api/apps/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/statuscheck"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/services/business"
+)
+
+// applyWaitTimeout bounds how long ApplyIstioConfig waits for each affected Deployment
+// to settle before giving up and reporting whatever state it's in. Deployments are
+// waited on one after another, each with its own timeout.
+const applyWaitTimeout = 60 * time.Second
+
+// ApplyIstioConfig method replaces the named Istio config object with the request
+// body (the same decode/validate path CreateIstioConfig/UpdateIstioConfig use), then
+// waits for the Deployments behind the object's target service to reach a ready state
+// using kubernetes/statuscheck's readiness rules, so a caller driving a canary rollout
+// knows when it's safe to proceed rather than polling itself. Object kinds with no
+// single target service (mixer Rules) fall back to waiting on the whole namespace.
+func ApplyIstioConfig(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not found: "+objectType)
+		return
+	}
+
+	decoded, biz, ok := decodeAndValidateIstioObject(w, r, objectType)
+	if !ok {
+		return
+	}
+
+	applied, err := updateIstioObject(biz.Client, namespace, objectType, object, decoded)
+	if respondIstioConfigMutationError(w, err) {
+		return
+	}
+
+	deployments, err := affectedDeployments(biz, namespace, objectType, applied)
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	status := make(map[string]string, len(deployments))
+	for i := range deployments {
+		name := deployments[i].Name
+		reason, err := statuscheck.WaitUntilReady(r.Context(), biz.Client.Kube(), func() (runtime.Object, error) {
+			return biz.Client.Kube().AppsV1beta1().Deployments(namespace).Get(name, meta_v1.GetOptions{})
+		}, applyWaitTimeout)
+		if err != nil {
+			status[name] = "not ready: " + err.Error()
+			continue
+		}
+		status[name] = reason
+	}
+
+	log.Infof("ApplyIstioConfig: applied %s/%s in %s, waited on %d deployment(s)", objectType, object, namespace, len(deployments))
+	RespondWithJSON(w, http.StatusOK, status)
+}
+
+// affectedDeployments narrows the Deployments ApplyIstioConfig waits on to the ones
+// backing the service object actually targets (its spec.host/spec.hosts[0]/
+// spec.destination.name, depending on objectType), instead of every Deployment in
+// namespace. objectType kinds with no single target service (mixer Rules) fall back
+// to the whole namespace, since there's nothing narrower to resolve.
+func affectedDeployments(biz *business.Layer, namespace string, objectType string, object kubernetes.IstioObject) ([]v1beta1.Deployment, error) {
+	host := targetHost(objectType, object)
+	if host == "" {
+		deployments, err := biz.Client.GetDeployments(namespace)
+		if err != nil {
+			return nil, err
+		}
+		return deployments.Items, nil
+	}
+
+	serviceName := strings.SplitN(host, ".", 2)[0]
+	svc, err := biz.Client.GetService(namespace, serviceName)
+	if err != nil {
+		return nil, err
+	}
+
+	pods, err := biz.Client.GetPods(namespace, labels.Set(svc.Spec.Selector))
+	if err != nil {
+		return nil, err
+	}
+
+	deployments, err := biz.Client.GetDeployments(namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubernetes.FilterDeploymentsForService(svc, pods, deployments), nil
+}
+
+// targetHost returns the single service host object (of objectType) targets, or ""
+// if its kind has no single target (mixer Rules reference handlers/instances, not a
+// destination service).
+func targetHost(objectType string, object kubernetes.IstioObject) string {
+	switch objectType {
+	case "routerules", "destinationpolicies":
+		if dest, ok := object.GetSpec()["destination"].(map[string]interface{}); ok {
+			if name, ok := dest["name"].(string); ok {
+				return name
+			}
+		}
+	case "virtualservices":
+		if hosts, ok := object.GetSpec()["hosts"].([]interface{}); ok && len(hosts) > 0 {
+			if host, ok := hosts[0].(string); ok {
+				return host
+			}
+		}
+	case "destinationrules":
+		if host, ok := object.GetSpec()["host"].(string); ok {
+			return host
+		}
+	}
+	return ""
+}
@@ -6,11 +6,26 @@ import (
 
 	"github.com/gorilla/mux"
 
+	"github.com/kiali/kiali/kubernetes"
 	"github.com/kiali/kiali/log"
 	"github.com/kiali/kiali/services/business"
 	"k8s.io/apimachinery/pkg/api/errors"
 )
 
+// clusterParam returns which cluster r targets: the `cluster` path variable when the
+// route was mounted under /api/clusters/{cluster}, otherwise the `?cluster=` query
+// parameter so routes without a clustered alias can still reach a remote cluster, and
+// kubernetes.LocalCluster when neither is set.
+func clusterParam(r *http.Request) string {
+	if cluster, ok := mux.Vars(r)["cluster"]; ok && cluster != "" {
+		return cluster
+	}
+	if cluster := r.URL.Query().Get("cluster"); cluster != "" {
+		return cluster
+	}
+	return kubernetes.LocalCluster
+}
+
 // SwitchRoute method
 func SwitchRoute(w http.ResponseWriter, r *http.Request) {
 	params := mux.Vars(r)
@@ -23,7 +38,7 @@ func SwitchRoute(w http.ResponseWriter, r *http.Request) {
 	criteria := parseCriteria(namespace, objects)
 
 	// Get business layer
-	business, err := business.Get()
+	business, err := business.Get(clusterParam(r))
 	if err != nil {
 		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
 		return
@@ -52,7 +67,7 @@ func IstioConfigList(w http.ResponseWriter, r *http.Request) {
 	criteria := parseCriteria(namespace, objects)
 
 	// Get business layer
-	business, err := business.Get()
+	business, err := business.Get(clusterParam(r))
 	if err != nil {
 		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
 		return
@@ -122,7 +137,7 @@ func IstioConfigDetails(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get business layer
-	business, err := business.Get()
+	business, err := business.Get(clusterParam(r))
 	if err != nil {
 		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
 		return
@@ -155,7 +170,7 @@ func IstioConfigValidations(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get business layer
-	business, err := business.Get()
+	business, err := business.Get(clusterParam(r))
 	if err != nil {
 		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
 		return
@@ -0,0 +1,234 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/services/business"
+)
+
+// CreateIstioConfig method creates an Istio config object of the given object_type in
+// namespace from the request body. The object is validated with the same rules
+// IstioConfigValidations exposes before being persisted: a failing validation report
+// is returned as a 422 instead of being silently written to the cluster.
+func CreateIstioConfig(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not found: "+objectType)
+		return
+	}
+
+	object, biz, ok := decodeAndValidateIstioObject(w, r, objectType)
+	if !ok {
+		return
+	}
+
+	created, err := createIstioObject(biz.Client, namespace, objectType, object)
+	if respondIstioConfigMutationError(w, err) {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusCreated, created)
+}
+
+// UpdateIstioConfig method updates the Istio config object named object of the given
+// object_type in namespace. A `Content-Type: application/merge-patch+json` request
+// applies the body as a JSON merge patch (RFC 7386) against the existing object
+// instead of replacing it wholesale, so incremental changes (weight shifts, subset
+// additions) don't require resending the whole object.
+func UpdateIstioConfig(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not found: "+objectType)
+		return
+	}
+
+	if r.Header.Get("Content-Type") == "application/merge-patch+json" {
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "Cannot read request body: "+err.Error())
+			return
+		}
+
+		biz, err := business.Get(clusterParam(r))
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+			return
+		}
+
+		patched, err := biz.Client.PatchIstioObject(namespace, objectType, object, body, &kubernetes.GenericIstioObject{})
+		if respondIstioConfigMutationError(w, err) {
+			return
+		}
+
+		RespondWithJSON(w, http.StatusOK, patched)
+		return
+	}
+
+	decoded, biz, ok := decodeAndValidateIstioObject(w, r, objectType)
+	if !ok {
+		return
+	}
+
+	updated, err := updateIstioObject(biz.Client, namespace, objectType, object, decoded)
+	if respondIstioConfigMutationError(w, err) {
+		return
+	}
+
+	RespondWithJSON(w, http.StatusOK, updated)
+}
+
+// DeleteIstioConfig method deletes the Istio config object named object of the given
+// object_type in namespace.
+func DeleteIstioConfig(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	objectType := params["object_type"]
+	object := params["object"]
+
+	if !checkObjectType(objectType) {
+		RespondWithError(w, http.StatusBadRequest, "Object type not found: "+objectType)
+		return
+	}
+
+	biz, err := business.Get(clusterParam(r))
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	err = deleteIstioObject(biz.Client, namespace, objectType, object)
+	if errors.IsNotFound(err) {
+		RespondWithError(w, http.StatusNotFound, err.Error())
+		return
+	} else if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// decodeAndValidateIstioObject reads r's body into a GenericIstioObject, validates it
+// through the business layer, and writes the appropriate error response (bad request
+// on unparsable JSON, 422 on a failing validation report) if either step fails. ok is
+// false whenever w already has a response written.
+func decodeAndValidateIstioObject(w http.ResponseWriter, r *http.Request, objectType string) (*kubernetes.GenericIstioObject, *business.Layer, bool) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Cannot read request body: "+err.Error())
+		return nil, nil, false
+	}
+
+	object := &kubernetes.GenericIstioObject{}
+	if err := json.Unmarshal(body, object); err != nil {
+		RespondWithError(w, http.StatusBadRequest, "Cannot parse Istio object: "+err.Error())
+		return nil, nil, false
+	}
+
+	biz, err := business.Get(clusterParam(r))
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return nil, nil, false
+	}
+
+	if validations := biz.Validations.ValidateIstioObject(objectType, object); !validations.Valid {
+		RespondWithJSON(w, http.StatusUnprocessableEntity, validations)
+		return nil, nil, false
+	}
+
+	return object, biz, true
+}
+
+// createIstioObject dispatches to the typed Create method kubernetes.IstioClient
+// exposes for objectType.
+func createIstioObject(client *kubernetes.IstioClient, namespace string, objectType string, object kubernetes.IstioObject) (kubernetes.IstioObject, error) {
+	switch objectType {
+	case "routerules":
+		return client.CreateRouteRule(namespace, object)
+	case "destinationpolicies":
+		return client.CreateDestinationPolicy(namespace, object)
+	case "virtualservices":
+		return client.CreateVirtualService(namespace, object)
+	case "destinationrules":
+		return client.CreateDestinationRule(namespace, object)
+	case "rules":
+		return client.CreateRule(namespace, object)
+	default:
+		return nil, fmt.Errorf("unknown Istio object type: %s", objectType)
+	}
+}
+
+// updateIstioObject dispatches to the typed Update method kubernetes.IstioClient
+// exposes for objectType.
+func updateIstioObject(client *kubernetes.IstioClient, namespace string, objectType string, name string, object kubernetes.IstioObject) (kubernetes.IstioObject, error) {
+	switch objectType {
+	case "routerules":
+		return client.UpdateRouteRule(namespace, name, object)
+	case "destinationpolicies":
+		return client.UpdateDestinationPolicy(namespace, name, object)
+	case "virtualservices":
+		return client.UpdateVirtualService(namespace, name, object)
+	case "destinationrules":
+		return client.UpdateDestinationRule(namespace, name, object)
+	case "rules":
+		return client.UpdateRule(namespace, name, object)
+	default:
+		return nil, fmt.Errorf("unknown Istio object type: %s", objectType)
+	}
+}
+
+// deleteIstioObject dispatches to the typed Delete method kubernetes.IstioClient
+// exposes for objectType.
+func deleteIstioObject(client *kubernetes.IstioClient, namespace string, objectType string, name string) error {
+	switch objectType {
+	case "routerules":
+		return client.DeleteRouteRule(namespace, name)
+	case "destinationpolicies":
+		return client.DeleteDestinationPolicy(namespace, name)
+	case "virtualservices":
+		return client.DeleteVirtualService(namespace, name)
+	case "destinationrules":
+		return client.DeleteDestinationRule(namespace, name)
+	case "rules":
+		return client.DeleteRule(namespace, name)
+	default:
+		return fmt.Errorf("unknown Istio object type: %s", objectType)
+	}
+}
+
+// respondIstioConfigMutationError writes the appropriate error response for err, if
+// any, and reports whether it did so (so callers can early-return).
+func respondIstioConfigMutationError(w http.ResponseWriter, err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if errors.IsNotFound(err) {
+		RespondWithError(w, http.StatusNotFound, err.Error())
+		return true
+	}
+	if statusError, isStatus := err.(*errors.StatusError); isStatus {
+		RespondWithError(w, http.StatusInternalServerError, statusError.ErrStatus.Message)
+		return true
+	}
+
+	log.Error(err)
+	RespondWithError(w, http.StatusInternalServerError, err.Error())
+	return true
+}
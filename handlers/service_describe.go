@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/envoy"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/services/business"
+)
+
+// ServiceDescribe method produces an istioctl-describe-style report of what is
+// actually applied to each pod backing a service, by correlating its Envoy sidecars'
+// config dumps against the VirtualServices/DestinationRules Kiali knows about for that
+// service.
+func ServiceDescribe(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	service := params["service"]
+
+	// Get business layer
+	biz, err := business.Get(clusterParam(r))
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	svc, err := biz.Client.GetService(namespace, service)
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	pods, err := biz.Client.GetPods(namespace, labels.Set(svc.Spec.Selector))
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	details, err := biz.Client.GetIstioDetails(namespace, service)
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	reports := make([]*envoy.PodReport, 0, len(pods.Items))
+	for _, pod := range pods.Items {
+		dump, err := biz.Client.GetPodConfigDump(namespace, pod.Name)
+		if err != nil {
+			log.Errorf("ServiceDescribe: unable to fetch config dump for pod %s: %v", pod.Name, err)
+			continue
+		}
+
+		parsed, err := envoy.Decode(dump)
+		if err != nil {
+			log.Errorf("ServiceDescribe: unable to decode config dump for pod %s: %v", pod.Name, err)
+			continue
+		}
+
+		reports = append(reports, envoy.BuildReport(pod.Name, parsed, details))
+	}
+
+	RespondWithJSON(w, http.StatusOK, reports)
+}
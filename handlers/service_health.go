@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/kiali/kiali/kubernetes"
+	"github.com/kiali/kiali/kubernetes/statuscheck"
+	"github.com/kiali/kiali/log"
+	"github.com/kiali/kiali/services/business"
+)
+
+// WorkloadStatus reports one Deployment's rollout readiness, as kubernetes/statuscheck
+// sees it, including the human-readable reason when it isn't ready yet.
+type WorkloadStatus struct {
+	Name   string `json:"name"`
+	Ready  bool   `json:"ready"`
+	Reason string `json:"reason"`
+}
+
+// ServiceHealthReport is the per-workload readiness report ServiceHealth responds with.
+type ServiceHealthReport struct {
+	WorkloadStatuses []WorkloadStatus `json:"workloadStatuses"`
+}
+
+// ServiceHealth method reports the rollout readiness of every Deployment backing
+// service, via kubernetes/statuscheck's Helm-3-style rules, rather than the boolean
+// FilterDeploymentsForService-based signal this replaces.
+func ServiceHealth(w http.ResponseWriter, r *http.Request) {
+	params := mux.Vars(r)
+	namespace := params["namespace"]
+	service := params["service"]
+
+	biz, err := business.Get(clusterParam(r))
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, "Services initialization error: "+err.Error())
+		return
+	}
+
+	svc, err := biz.Client.GetService(namespace, service)
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	pods, err := biz.Client.GetPods(namespace, labels.Set(svc.Spec.Selector))
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	deployments, err := biz.Client.GetDeployments(namespace)
+	if err != nil {
+		log.Error(err)
+		RespondWithError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	matched := kubernetes.FilterDeploymentsForService(svc, pods, deployments)
+
+	health := ServiceHealthReport{WorkloadStatuses: make([]WorkloadStatus, 0, len(matched))}
+	for i := range matched {
+		ready, reason, err := statuscheck.Ready(biz.Client.Kube(), &matched[i])
+		if err != nil {
+			log.Errorf("ServiceHealth: unable to determine readiness of deployment %s: %v", matched[i].Name, err)
+			continue
+		}
+		health.WorkloadStatuses = append(health.WorkloadStatuses, WorkloadStatus{
+			Name:   matched[i].Name,
+			Ready:  ready,
+			Reason: reason,
+		})
+	}
+
+	RespondWithJSON(w, http.StatusOK, health)
+}
@@ -0,0 +1,288 @@
+package kubernetes
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	kube "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/workqueue"
+
+	"github.com/kiali/kiali/log"
+)
+
+// LocalCluster is the name used for the cluster Kiali is itself running in, as
+// opposed to a remote cluster registered through a kubeconfig Secret.
+const LocalCluster = "local"
+
+// multiClusterSecretLabel is the label a Secret must carry, set to "true", to be
+// picked up by the ClusterSecretWatcher as a source of remote cluster kubeconfigs.
+const multiClusterSecretLabel = "istio/multiCluster"
+
+// ClusterInfo holds everything the business layer needs to talk to one cluster.
+type ClusterInfo struct {
+	Name    string
+	Client  *IstioClient
+	Version string
+
+	// checksum is the sha256 of the kubeconfig bytes this client was built from. It
+	// lets the watcher tell a no-op Update (e.g. label churn) from an actual
+	// credential/endpoint change that requires rebuilding the client.
+	checksum string
+}
+
+// ClusterRegistry is a thread-safe store of the IstioClient built for each cluster
+// Kiali currently knows about, keyed by cluster name. It is populated by
+// ClusterSecretWatcher and read by the business layer on every request that carries
+// a `cluster` selector.
+type ClusterRegistry struct {
+	mu       sync.RWMutex
+	clusters map[string]*ClusterInfo
+}
+
+// NewClusterRegistry returns an empty ClusterRegistry.
+func NewClusterRegistry() *ClusterRegistry {
+	return &ClusterRegistry{
+		clusters: make(map[string]*ClusterInfo),
+	}
+}
+
+// Get returns the ClusterInfo registered under name, if any.
+func (r *ClusterRegistry) Get(name string) (*ClusterInfo, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	info, ok := r.clusters[name]
+	return info, ok
+}
+
+// List returns every registered cluster, in no particular order.
+func (r *ClusterRegistry) List() []*ClusterInfo {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	list := make([]*ClusterInfo, 0, len(r.clusters))
+	for _, info := range r.clusters {
+		list = append(list, info)
+	}
+	return list
+}
+
+// checksumFor returns the registered checksum for name, or "" if name isn't registered.
+func (r *ClusterRegistry) checksumFor(name string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if info, ok := r.clusters[name]; ok {
+		return info.checksum
+	}
+	return ""
+}
+
+func (r *ClusterRegistry) set(info *ClusterInfo) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.clusters[info.Name] = info
+}
+
+func (r *ClusterRegistry) remove(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.clusters, name)
+}
+
+// Clusters is the process-wide registry populated by the ClusterSecretWatcher started
+// from main. The business layer reads from it whenever a request targets a cluster
+// other than LocalCluster.
+var Clusters = NewClusterRegistry()
+
+// ClusterSecretWatcher watches, in a single namespace (the Kiali install namespace),
+// for Secrets labeled `istio/multiCluster=true`. Each data entry of such a Secret is
+// expected to be a kubeconfig for a remote cluster, keyed by cluster name. On
+// Add/Update/Delete it builds or tears down the corresponding IstioClient and keeps
+// ClusterRegistry in sync, using client-go's informer/workqueue pattern so that
+// transient API errors are retried instead of dropped.
+type ClusterSecretWatcher struct {
+	registry  *ClusterRegistry
+	namespace string
+
+	informer cache.SharedIndexInformer
+	queue    workqueue.RateLimitingInterface
+
+	// keysMu guards clusterNamesBySecret below.
+	keysMu sync.Mutex
+	// clusterNamesBySecret records, per Secret key, the cluster names it contributed
+	// on the last successful sync. syncSecret diffs against this to tell a cluster
+	// whose key was removed from an Update (Secret still exists, key doesn't) from one
+	// that was never there to begin with, since secret.Data only ever tells us what's
+	// present now.
+	clusterNamesBySecret map[string]map[string]bool
+}
+
+// NewClusterSecretWatcher builds a watcher for multi-cluster kubeconfig Secrets living
+// in namespace. Call Run to start it.
+func NewClusterSecretWatcher(registry *ClusterRegistry, k8s kube.Interface, namespace string) *ClusterSecretWatcher {
+	selector := labels.Set{multiClusterSecretLabel: "true"}.AsSelector()
+
+	w := &ClusterSecretWatcher{
+		registry:             registry,
+		namespace:            namespace,
+		queue:                workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		clusterNamesBySecret: make(map[string]map[string]bool),
+	}
+
+	listWatch := &cache.ListWatch{
+		ListFunc: func(options meta_v1.ListOptions) (runtime.Object, error) {
+			options.LabelSelector = selector.String()
+			return k8s.CoreV1().Secrets(namespace).List(options)
+		},
+		WatchFunc: func(options meta_v1.ListOptions) (watch.Interface, error) {
+			options.LabelSelector = selector.String()
+			return k8s.CoreV1().Secrets(namespace).Watch(options)
+		},
+	}
+	w.informer = cache.NewSharedIndexInformer(listWatch, &v1.Secret{}, 0, cache.Indexers{})
+	w.informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    w.enqueue,
+		UpdateFunc: func(_, newObj interface{}) { w.enqueue(newObj) },
+		DeleteFunc: w.enqueue,
+	})
+
+	return w
+}
+
+// Run starts the informer and processes the workqueue until stopCh is closed.
+func (w *ClusterSecretWatcher) Run(stopCh <-chan struct{}) {
+	defer w.queue.ShutDown()
+
+	go w.informer.Run(stopCh)
+	if !cache.WaitForCacheSync(stopCh, w.informer.HasSynced) {
+		log.Error("ClusterSecretWatcher: timed out waiting for informer cache to sync")
+		return
+	}
+
+	go wait.Until(w.runWorker, time.Second, stopCh)
+	<-stopCh
+}
+
+func (w *ClusterSecretWatcher) enqueue(obj interface{}) {
+	key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj)
+	if err != nil {
+		log.Errorf("ClusterSecretWatcher: couldn't get key for object: %v", err)
+		return
+	}
+	w.queue.Add(key)
+}
+
+func (w *ClusterSecretWatcher) runWorker() {
+	for w.processNextItem() {
+	}
+}
+
+func (w *ClusterSecretWatcher) processNextItem() bool {
+	key, quit := w.queue.Get()
+	if quit {
+		return false
+	}
+	defer w.queue.Done(key)
+
+	if err := w.syncSecret(key.(string)); err != nil {
+		log.Errorf("ClusterSecretWatcher: error syncing %s, retrying: %v", key, err)
+		w.queue.AddRateLimited(key)
+		return true
+	}
+	w.queue.Forget(key)
+	return true
+}
+
+// syncSecret reconciles ClusterRegistry against the current state of the Secret named
+// by key. It diffs kubeconfig contents (not merely presence) so that an Update which
+// doesn't actually change a cluster's kubeconfig doesn't pay the cost of rebuilding
+// its IstioClient, and rebuilds only the clusters whose entry did change.
+func (w *ClusterSecretWatcher) syncSecret(key string) error {
+	obj, exists, err := w.informer.GetIndexer().GetByKey(key)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		// The Secret is gone: tear down every cluster it had contributed.
+		w.removeClustersFor(key, nil)
+		return nil
+	}
+
+	secret := obj.(*v1.Secret)
+	current := make(map[string]bool, len(secret.Data))
+	for clusterName, kubeconfig := range secret.Data {
+		current[clusterName] = true
+
+		sum := checksum(kubeconfig)
+		if w.registry.checksumFor(clusterName) == sum {
+			continue
+		}
+
+		restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+		if err != nil {
+			log.Errorf("ClusterSecretWatcher: invalid kubeconfig for cluster %s in secret %s: %v", clusterName, key, err)
+			continue
+		}
+
+		client, err := NewClientFromConfig(restConfig)
+		if err != nil {
+			log.Errorf("ClusterSecretWatcher: unable to build client for cluster %s: %v", clusterName, err)
+			continue
+		}
+
+		version, err := client.IstioVersion(w.namespace)
+		if err != nil {
+			log.Infof("ClusterSecretWatcher: unable to determine Istio version for cluster %s: %v", clusterName, err)
+		}
+
+		w.registry.set(&ClusterInfo{
+			Name:     clusterName,
+			Client:   client,
+			Version:  version,
+			checksum: sum,
+		})
+		log.Infof("ClusterSecretWatcher: registered cluster %s", clusterName)
+	}
+
+	// Tear down any cluster this same Secret contributed previously but whose key is
+	// no longer present, even though the Secret itself still exists.
+	w.removeClustersFor(key, current)
+
+	return nil
+}
+
+// removeClustersFor removes every cluster key previously recorded for the Secret
+// named key but absent from current (nil removes all of them, for a deleted Secret),
+// and updates the record to current.
+func (w *ClusterSecretWatcher) removeClustersFor(key string, current map[string]bool) {
+	w.keysMu.Lock()
+	previous := w.clusterNamesBySecret[key]
+	if current == nil {
+		delete(w.clusterNamesBySecret, key)
+	} else {
+		w.clusterNamesBySecret[key] = current
+	}
+	w.keysMu.Unlock()
+
+	for clusterName := range previous {
+		if clusterName == LocalCluster || current[clusterName] {
+			continue
+		}
+		w.registry.remove(clusterName)
+		log.Infof("ClusterSecretWatcher: unregistered cluster %s", clusterName)
+	}
+}
+
+func checksum(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,80 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterRegistryGetAndList(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewClusterRegistry()
+	registry.set(&ClusterInfo{Name: "east", checksum: "abc"})
+	registry.set(&ClusterInfo{Name: "west", checksum: "def"})
+
+	info, found := registry.Get("east")
+	assert.True(found)
+	assert.Equal("east", info.Name)
+
+	_, found = registry.Get("missing")
+	assert.False(found)
+
+	assert.Len(registry.List(), 2)
+}
+
+func TestClusterRegistryRemove(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewClusterRegistry()
+	registry.set(&ClusterInfo{Name: "east", checksum: "abc"})
+	registry.remove("east")
+
+	_, found := registry.Get("east")
+	assert.False(found)
+}
+
+func TestRemoveClustersForDropsStaleKey(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewClusterRegistry()
+	registry.set(&ClusterInfo{Name: "east", checksum: "abc"})
+	registry.set(&ClusterInfo{Name: "west", checksum: "def"})
+
+	w := &ClusterSecretWatcher{registry: registry, clusterNamesBySecret: make(map[string]map[string]bool)}
+	w.removeClustersFor("kiali/multicluster-secret", map[string]bool{"east": true, "west": true})
+
+	// "west" is dropped from the secret on a later sync, even though the secret itself
+	// still exists.
+	w.removeClustersFor("kiali/multicluster-secret", map[string]bool{"east": true})
+
+	_, found := registry.Get("west")
+	assert.False(found)
+	_, found = registry.Get("east")
+	assert.True(found)
+}
+
+func TestRemoveClustersForWholeSecretDeleteDropsEverything(t *testing.T) {
+	assert := assert.New(t)
+
+	registry := NewClusterRegistry()
+	registry.set(&ClusterInfo{Name: "east", checksum: "abc"})
+
+	w := &ClusterSecretWatcher{registry: registry, clusterNamesBySecret: make(map[string]map[string]bool)}
+	w.removeClustersFor("kiali/multicluster-secret", map[string]bool{"east": true})
+	w.removeClustersFor("kiali/multicluster-secret", nil)
+
+	_, found := registry.Get("east")
+	assert.False(found)
+}
+
+func TestChecksumDetectsContentChange(t *testing.T) {
+	assert := assert.New(t)
+
+	original := checksum([]byte("kubeconfig-v1"))
+	sameAgain := checksum([]byte("kubeconfig-v1"))
+	changed := checksum([]byte("kubeconfig-v2"))
+
+	assert.Equal(original, sameAgain)
+	assert.NotEqual(original, changed)
+}
@@ -0,0 +1,244 @@
+package kubernetes
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+
+	"github.com/kiali/kiali/log"
+)
+
+// istioCrudKind describes how to reach one kind of Istio config object through the
+// generic REST clients NewClient sets up: which of the two (config.istio.io vs
+// networking.istio.io) owns it, and its resource (plural, lower-case) name.
+type istioCrudKind struct {
+	api      func(*IstioClient) *rest.RESTClient
+	resource string
+}
+
+var istioCrudKinds = map[string]istioCrudKind{
+	"routerules":          {api: (*IstioClient).configAPI, resource: "routerules"},
+	"destinationpolicies": {api: (*IstioClient).configAPI, resource: "destinationpolicies"},
+	"rules":               {api: (*IstioClient).configAPI, resource: "rules"},
+	"virtualservices":     {api: (*IstioClient).networkingAPI, resource: "virtualservices"},
+	"destinationrules":    {api: (*IstioClient).networkingAPI, resource: "destinationrules"},
+}
+
+func (client *IstioClient) configAPI() *rest.RESTClient     { return client.istioConfigApi }
+func (client *IstioClient) networkingAPI() *rest.RESTClient { return client.istioNetworkingApi }
+
+func istioCrudKindFor(objectType string) (istioCrudKind, error) {
+	kind, ok := istioCrudKinds[objectType]
+	if !ok {
+		return istioCrudKind{}, fmt.Errorf("unknown Istio object type: %s", objectType)
+	}
+	return kind, nil
+}
+
+// createIstioObject persists object as a new instance of objectType in namespace. On
+// success object is updated in place with the server's response (resourceVersion,
+// defaulted fields, etc.) and also returned for convenience.
+func (client *IstioClient) createIstioObject(objectType string, namespace string, object IstioObject) (IstioObject, error) {
+	kind, err := istioCrudKindFor(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = kind.api(client).Post().
+		Namespace(namespace).
+		Resource(kind.resource).
+		Body(object).
+		Do().
+		Into(object)
+	if err != nil {
+		return nil, err
+	}
+
+	client.recordConfigEvent(namespace, objectType, object.GetObjectMeta().Name, "Created", "Istio config object created")
+	return object, nil
+}
+
+// updateIstioObject replaces the whole spec of the named objectType/name with object.
+func (client *IstioClient) updateIstioObject(objectType string, namespace string, name string, object IstioObject) (IstioObject, error) {
+	kind, err := istioCrudKindFor(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	err = kind.api(client).Put().
+		Namespace(namespace).
+		Resource(kind.resource).
+		Name(name).
+		Body(object).
+		Do().
+		Into(object)
+	if err != nil {
+		return nil, err
+	}
+
+	client.recordConfigEvent(namespace, objectType, name, "Updated", "Istio config object updated")
+	return object, nil
+}
+
+// PatchIstioObject applies jsonPatch as a JSON merge patch (RFC 7386) to the named
+// objectType/name, so that UI-driven canary workflows (weight shifts, subset
+// additions) can send only the fields that changed instead of the whole object.
+// into is used only to learn the concrete Go type to decode the server's response
+// into; it is not itself mutated.
+func (client *IstioClient) PatchIstioObject(namespace string, objectType string, name string, jsonPatch []byte, into IstioObject) (IstioObject, error) {
+	kind, err := istioCrudKindFor(objectType)
+	if err != nil {
+		return nil, err
+	}
+
+	result := newIstioObjectLike(into)
+	err = kind.api(client).Patch(types.MergePatchType).
+		Namespace(namespace).
+		Resource(kind.resource).
+		Name(name).
+		Body(jsonPatch).
+		Do().
+		Into(result)
+	if err != nil {
+		return nil, err
+	}
+
+	client.recordConfigEvent(namespace, objectType, name, "Patched", "Istio config object patched")
+	return result, nil
+}
+
+// newIstioObjectLike returns a new, empty value of the same concrete type as like, so
+// PatchIstioObject can decode a response without the kubernetes package needing to
+// know every concrete IstioObject implementation by name.
+func newIstioObjectLike(like IstioObject) IstioObject {
+	t := reflect.TypeOf(like)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return reflect.New(t).Interface().(IstioObject)
+}
+
+// deleteIstioObject removes the named objectType/name.
+func (client *IstioClient) deleteIstioObject(objectType string, namespace string, name string) error {
+	kind, err := istioCrudKindFor(objectType)
+	if err != nil {
+		return err
+	}
+
+	err = kind.api(client).Delete().
+		Namespace(namespace).
+		Resource(kind.resource).
+		Name(name).
+		Do().
+		Error()
+	if err != nil {
+		return err
+	}
+
+	client.recordConfigEvent(namespace, objectType, name, "Deleted", "Istio config object deleted")
+	return nil
+}
+
+// recordConfigEvent emits a Kubernetes Event against the mutated object so that
+// `kubectl describe`/audit tooling surfaces who changed Istio config and when, the
+// same way Kubernetes itself records Events for workload mutations.
+func (client *IstioClient) recordConfigEvent(namespace string, objectType string, name string, reason string, message string) {
+	event := &v1.Event{
+		ObjectMeta: meta_v1.ObjectMeta{
+			GenerateName: name + "-",
+			Namespace:    namespace,
+		},
+		InvolvedObject: v1.ObjectReference{
+			Kind:      objectType,
+			Namespace: namespace,
+			Name:      name,
+		},
+		Reason:  reason,
+		Message: message,
+		Source:  v1.EventSource{Component: "kiali"},
+		Type:    v1.EventTypeNormal,
+	}
+
+	if _, err := client.k8s.CoreV1().Events(namespace).Create(event); err != nil {
+		log.Errorf("unable to record event for %s/%s %s: %v", objectType, name, reason, err)
+	}
+}
+
+// CreateRouteRule creates a new RouteRule in namespace.
+func (client *IstioClient) CreateRouteRule(namespace string, routerule IstioObject) (IstioObject, error) {
+	return client.createIstioObject("routerules", namespace, routerule)
+}
+
+// UpdateRouteRule replaces the RouteRule named name in namespace.
+func (client *IstioClient) UpdateRouteRule(namespace string, name string, routerule IstioObject) (IstioObject, error) {
+	return client.updateIstioObject("routerules", namespace, name, routerule)
+}
+
+// DeleteRouteRule deletes the RouteRule named name in namespace.
+func (client *IstioClient) DeleteRouteRule(namespace string, name string) error {
+	return client.deleteIstioObject("routerules", namespace, name)
+}
+
+// CreateDestinationPolicy creates a new DestinationPolicy in namespace.
+func (client *IstioClient) CreateDestinationPolicy(namespace string, destinationpolicy IstioObject) (IstioObject, error) {
+	return client.createIstioObject("destinationpolicies", namespace, destinationpolicy)
+}
+
+// UpdateDestinationPolicy replaces the DestinationPolicy named name in namespace.
+func (client *IstioClient) UpdateDestinationPolicy(namespace string, name string, destinationpolicy IstioObject) (IstioObject, error) {
+	return client.updateIstioObject("destinationpolicies", namespace, name, destinationpolicy)
+}
+
+// DeleteDestinationPolicy deletes the DestinationPolicy named name in namespace.
+func (client *IstioClient) DeleteDestinationPolicy(namespace string, name string) error {
+	return client.deleteIstioObject("destinationpolicies", namespace, name)
+}
+
+// CreateVirtualService creates a new VirtualService in namespace.
+func (client *IstioClient) CreateVirtualService(namespace string, virtualservice IstioObject) (IstioObject, error) {
+	return client.createIstioObject("virtualservices", namespace, virtualservice)
+}
+
+// UpdateVirtualService replaces the VirtualService named name in namespace.
+func (client *IstioClient) UpdateVirtualService(namespace string, name string, virtualservice IstioObject) (IstioObject, error) {
+	return client.updateIstioObject("virtualservices", namespace, name, virtualservice)
+}
+
+// DeleteVirtualService deletes the VirtualService named name in namespace.
+func (client *IstioClient) DeleteVirtualService(namespace string, name string) error {
+	return client.deleteIstioObject("virtualservices", namespace, name)
+}
+
+// CreateDestinationRule creates a new DestinationRule in namespace.
+func (client *IstioClient) CreateDestinationRule(namespace string, destinationrule IstioObject) (IstioObject, error) {
+	return client.createIstioObject("destinationrules", namespace, destinationrule)
+}
+
+// UpdateDestinationRule replaces the DestinationRule named name in namespace.
+func (client *IstioClient) UpdateDestinationRule(namespace string, name string, destinationrule IstioObject) (IstioObject, error) {
+	return client.updateIstioObject("destinationrules", namespace, name, destinationrule)
+}
+
+// DeleteDestinationRule deletes the DestinationRule named name in namespace.
+func (client *IstioClient) DeleteDestinationRule(namespace string, name string) error {
+	return client.deleteIstioObject("destinationrules", namespace, name)
+}
+
+// CreateRule creates a new Istio mixer Rule in namespace.
+func (client *IstioClient) CreateRule(namespace string, rule IstioObject) (IstioObject, error) {
+	return client.createIstioObject("rules", namespace, rule)
+}
+
+// UpdateRule replaces the Istio mixer Rule named name in namespace.
+func (client *IstioClient) UpdateRule(namespace string, name string, rule IstioObject) (IstioObject, error) {
+	return client.updateIstioObject("rules", namespace, name, rule)
+}
+
+// DeleteRule deletes the Istio mixer Rule named name in namespace.
+func (client *IstioClient) DeleteRule(namespace string, name string) error {
+	return client.deleteIstioObject("rules", namespace, name)
+}
@@ -0,0 +1,23 @@
+package kubernetes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIstioCrudKindForKnownTypes(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, objectType := range []string{"routerules", "destinationpolicies", "rules", "virtualservices", "destinationrules"} {
+		_, err := istioCrudKindFor(objectType)
+		assert.NoError(err, objectType)
+	}
+}
+
+func TestIstioCrudKindForUnknownType(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := istioCrudKindFor("not-a-real-type")
+	assert.Error(err)
+}
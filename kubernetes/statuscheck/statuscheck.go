@@ -0,0 +1,214 @@
+// Package statuscheck answers a single question for any Kubernetes object Kiali
+// mutates: is it actually rolled out yet? The rules mirror what Helm 3 applies for
+// `helm install --wait`, since a boolean "exists" or "all replicas requested" check
+// is not enough to tell a still-progressing rollout from a stuck one.
+package statuscheck
+
+import (
+	"fmt"
+
+	apps_v1beta1 "k8s.io/api/apps/v1beta1"
+	batch_v1 "k8s.io/api/batch/v1"
+	core_v1 "k8s.io/api/core/v1"
+	ext_v1beta1 "k8s.io/api/extensions/v1beta1"
+	apiext_v1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	kube "k8s.io/client-go/kubernetes"
+)
+
+// Ready reports whether obj has finished rolling out, with a human-readable reason
+// when it hasn't. client is only consulted for kinds whose readiness depends on
+// sibling objects the API doesn't surface on obj itself (currently: Deployment, which
+// must walk its owned ReplicaSets to confirm the newest one is the one that's live).
+func Ready(client kube.Interface, obj runtime.Object) (ready bool, reason string, err error) {
+	switch o := obj.(type) {
+	case *apps_v1beta1.Deployment:
+		return deploymentReady(client, o)
+	case *apps_v1beta1.StatefulSet:
+		return statefulSetReady(o)
+	case *ext_v1beta1.DaemonSet:
+		return daemonSetReady(o)
+	case *ext_v1beta1.ReplicaSet:
+		return replicaSetReady(o)
+	case *core_v1.Pod:
+		return podReady(o)
+	case *core_v1.PersistentVolumeClaim:
+		return pvcReady(o)
+	case *core_v1.Service:
+		return serviceReady(o)
+	case *batch_v1.Job:
+		return jobReady(o)
+	case *apiext_v1beta1.CustomResourceDefinition:
+		return crdReady(o)
+	default:
+		return false, "", fmt.Errorf("statuscheck: unsupported object kind %T", obj)
+	}
+}
+
+func deploymentReady(client kube.Interface, d *apps_v1beta1.Deployment) (bool, string, error) {
+	if d.Status.ObservedGeneration < d.Generation {
+		return false, "waiting: observed generation lags desired generation", nil
+	}
+
+	specReplicas := int32(1)
+	if d.Spec.Replicas != nil {
+		specReplicas = *d.Spec.Replicas
+	}
+
+	if d.Status.UpdatedReplicas != specReplicas {
+		return false, fmt.Sprintf("waiting: %d/%d replicas updated", d.Status.UpdatedReplicas, specReplicas), nil
+	}
+	if d.Status.Replicas != d.Status.UpdatedReplicas {
+		return false, fmt.Sprintf("waiting: %d old replicas pending termination", d.Status.Replicas-d.Status.UpdatedReplicas), nil
+	}
+	if d.Status.AvailableReplicas != d.Status.UpdatedReplicas {
+		return false, fmt.Sprintf("waiting: %d/%d updated replicas available", d.Status.AvailableReplicas, d.Status.UpdatedReplicas), nil
+	}
+
+	newReplicaSet, err := newestReplicaSetFor(client, d)
+	if err != nil {
+		return false, "", err
+	}
+	if newReplicaSet == nil {
+		return false, "waiting: new replica set not observed yet", nil
+	}
+	if newReplicaSet.Status.ReadyReplicas != specReplicas {
+		return false, fmt.Sprintf("waiting: new replica set %s has %d/%d ready replicas", newReplicaSet.Name, newReplicaSet.Status.ReadyReplicas, specReplicas), nil
+	}
+
+	return true, "deployment rolled out", nil
+}
+
+// newestReplicaSetFor stands in for deploymentutil.GetNewReplicaSet: it lists the
+// ReplicaSets owned by d and returns the most recently created one, taken to be the
+// one the rollout is converging on. Pod templates are not compared.
+func newestReplicaSetFor(client kube.Interface, d *apps_v1beta1.Deployment) (*ext_v1beta1.ReplicaSet, error) {
+	rsList, err := client.ExtensionsV1beta1().ReplicaSets(d.Namespace).List(meta_v1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var newest *ext_v1beta1.ReplicaSet
+	for i := range rsList.Items {
+		rs := &rsList.Items[i]
+		if !isOwnedBy(rs.OwnerReferences, d.UID) {
+			continue
+		}
+		if newest == nil || rs.CreationTimestamp.After(newest.CreationTimestamp.Time) {
+			newest = rs
+		}
+	}
+	return newest, nil
+}
+
+func isOwnedBy(refs []meta_v1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func statefulSetReady(s *apps_v1beta1.StatefulSet) (bool, string, error) {
+	specReplicas := int32(1)
+	if s.Spec.Replicas != nil {
+		specReplicas = *s.Spec.Replicas
+	}
+
+	if s.Spec.UpdateStrategy.RollingUpdate != nil && s.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		partition := *s.Spec.UpdateStrategy.RollingUpdate.Partition
+		expectedUpdated := specReplicas - partition
+		if s.Status.UpdatedReplicas < expectedUpdated {
+			return false, fmt.Sprintf("waiting: partitioned rollout, %d/%d replicas updated", s.Status.UpdatedReplicas, expectedUpdated), nil
+		}
+		return true, "statefulset rolled out (partitioned)", nil
+	}
+
+	if s.Status.UpdateRevision != s.Status.CurrentRevision {
+		if s.Status.UpdatedReplicas < specReplicas {
+			return false, fmt.Sprintf("waiting: %d/%d replicas updated", s.Status.UpdatedReplicas, specReplicas), nil
+		}
+	}
+	if s.Status.ReadyReplicas != specReplicas {
+		return false, fmt.Sprintf("waiting: %d/%d replicas ready", s.Status.ReadyReplicas, specReplicas), nil
+	}
+
+	return true, "statefulset rolled out", nil
+}
+
+func daemonSetReady(ds *ext_v1beta1.DaemonSet) (bool, string, error) {
+	if ds.Status.UpdatedNumberScheduled != ds.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("waiting: %d/%d nodes updated", ds.Status.UpdatedNumberScheduled, ds.Status.DesiredNumberScheduled), nil
+	}
+	if ds.Status.NumberReady != ds.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("waiting: %d/%d nodes ready", ds.Status.NumberReady, ds.Status.DesiredNumberScheduled), nil
+	}
+	return true, "daemonset rolled out", nil
+}
+
+func replicaSetReady(rs *ext_v1beta1.ReplicaSet) (bool, string, error) {
+	specReplicas := int32(1)
+	if rs.Spec.Replicas != nil {
+		specReplicas = *rs.Spec.Replicas
+	}
+	if rs.Status.ReadyReplicas != specReplicas {
+		return false, fmt.Sprintf("waiting: %d/%d replicas ready", rs.Status.ReadyReplicas, specReplicas), nil
+	}
+	return true, "replicaset rolled out", nil
+}
+
+func podReady(p *core_v1.Pod) (bool, string, error) {
+	if p.Status.Phase != core_v1.PodRunning {
+		return false, "waiting: pod phase is " + string(p.Status.Phase), nil
+	}
+	for _, c := range p.Status.Conditions {
+		if c.Type == core_v1.PodReady {
+			if c.Status == core_v1.ConditionTrue {
+				return true, "pod running and ready", nil
+			}
+			return false, "waiting: pod not ready: " + c.Message, nil
+		}
+	}
+	return false, "waiting: pod ready condition not reported yet", nil
+}
+
+func pvcReady(p *core_v1.PersistentVolumeClaim) (bool, string, error) {
+	if p.Status.Phase != core_v1.ClaimBound {
+		return false, "waiting: pvc phase is " + string(p.Status.Phase), nil
+	}
+	return true, "pvc bound", nil
+}
+
+func serviceReady(s *core_v1.Service) (bool, string, error) {
+	if s.Spec.Type != core_v1.ServiceTypeLoadBalancer {
+		return true, "service created", nil
+	}
+	if len(s.Status.LoadBalancer.Ingress) == 0 {
+		return false, "waiting: load balancer ingress not assigned yet", nil
+	}
+	return true, "load balancer ready", nil
+}
+
+func jobReady(j *batch_v1.Job) (bool, string, error) {
+	for _, c := range j.Status.Conditions {
+		if c.Type == batch_v1.JobComplete && c.Status == core_v1.ConditionTrue {
+			return true, "job complete", nil
+		}
+		if c.Type == batch_v1.JobFailed && c.Status == core_v1.ConditionTrue {
+			return false, "job failed: " + c.Message, nil
+		}
+	}
+	return false, "waiting: job still running", nil
+}
+
+func crdReady(c *apiext_v1beta1.CustomResourceDefinition) (bool, string, error) {
+	for _, cond := range c.Status.Conditions {
+		if cond.Type == apiext_v1beta1.Established && cond.Status == apiext_v1beta1.ConditionTrue {
+			return true, "crd established", nil
+		}
+	}
+	return false, "waiting: crd not established yet", nil
+}
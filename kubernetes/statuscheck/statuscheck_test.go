@@ -0,0 +1,89 @@
+package statuscheck
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	apps_v1beta1 "k8s.io/api/apps/v1beta1"
+	core_v1 "k8s.io/api/core/v1"
+	ext_v1beta1 "k8s.io/api/extensions/v1beta1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newDeployment(replicas int32) *apps_v1beta1.Deployment {
+	return &apps_v1beta1.Deployment{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-v1", Namespace: "bookinfo", UID: types.UID("dep-uid"), Generation: 2},
+		Spec:       apps_v1beta1.DeploymentSpec{Replicas: &replicas},
+		Status: apps_v1beta1.DeploymentStatus{
+			ObservedGeneration: 2,
+			Replicas:           replicas,
+			UpdatedReplicas:    replicas,
+			AvailableReplicas:  replicas,
+		},
+	}
+}
+
+func newOwnedReplicaSet(name string, ready int32, ownerUID types.UID) *ext_v1beta1.ReplicaSet {
+	return &ext_v1beta1.ReplicaSet{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:            name,
+			Namespace:       "bookinfo",
+			OwnerReferences: []meta_v1.OwnerReference{{UID: ownerUID}},
+		},
+		Status: ext_v1beta1.ReplicaSetStatus{ReadyReplicas: ready},
+	}
+}
+
+func TestDeploymentReady(t *testing.T) {
+	assert := assert.New(t)
+
+	d := newDeployment(3)
+	rs := newOwnedReplicaSet("reviews-v1-abc123", 3, d.UID)
+	client := fake.NewSimpleClientset(rs)
+
+	ready, reason, err := Ready(client, d)
+
+	assert.NoError(err)
+	assert.True(ready, reason)
+}
+
+func TestDeploymentNotReadyWhenNewReplicaSetStillProgressing(t *testing.T) {
+	assert := assert.New(t)
+
+	d := newDeployment(3)
+	rs := newOwnedReplicaSet("reviews-v1-abc123", 1, d.UID)
+	client := fake.NewSimpleClientset(rs)
+
+	ready, reason, err := Ready(client, d)
+
+	assert.NoError(err)
+	assert.False(ready)
+	assert.Contains(reason, "1/3")
+}
+
+func TestPodReady(t *testing.T) {
+	assert := assert.New(t)
+
+	pod := &core_v1.Pod{
+		Status: core_v1.PodStatus{
+			Phase: core_v1.PodRunning,
+			Conditions: []core_v1.PodCondition{
+				{Type: core_v1.PodReady, Status: core_v1.ConditionTrue},
+			},
+		},
+	}
+
+	ready, _, err := Ready(fake.NewSimpleClientset(), pod)
+
+	assert.NoError(err)
+	assert.True(ready)
+}
+
+func TestUnsupportedKindReturnsError(t *testing.T) {
+	assert := assert.New(t)
+
+	_, _, err := Ready(fake.NewSimpleClientset(), &core_v1.Namespace{})
+	assert.Error(err)
+}
@@ -0,0 +1,53 @@
+package statuscheck
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	kube "k8s.io/client-go/kubernetes"
+
+	"github.com/kiali/kiali/log"
+)
+
+// pollInterval is the base delay between two Ready checks; jitter is added on top so
+// that many concurrent WaitUntilReady calls (e.g. one per object in a bulk apply)
+// don't all hammer the API server in lockstep.
+const pollInterval = 2 * time.Second
+
+// WaitUntilReady polls Ready for obj until it reports ready, ctx is cancelled, or
+// timeout elapses, whichever happens first. fetch is called before every Ready check
+// so obj reflects the latest server state, since the caller's copy goes stale the
+// moment the object starts rolling out.
+func WaitUntilReady(ctx context.Context, client kube.Interface, fetch func() (runtime.Object, error), timeout time.Duration) (reason string, err error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		obj, err := fetch()
+		if err != nil {
+			return "", err
+		}
+
+		ready, reason, err := Ready(client, obj)
+		if err != nil {
+			return "", err
+		}
+		if ready {
+			return reason, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return reason, ctx.Err()
+		case <-time.After(jitter(pollInterval)):
+			log.Infof("statuscheck: still waiting: %s", reason)
+		}
+	}
+}
+
+func jitter(base time.Duration) time.Duration {
+	delta := time.Duration(rand.Int63n(int64(base) / 2))
+	return base + delta
+}
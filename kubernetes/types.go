@@ -0,0 +1,186 @@
+package kubernetes
+
+import (
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// istioConfigGroupVersion is the group/version the legacy istioConfigApi RESTClient
+// talks to, for the config.istio.io CRDs (RouteRule, DestinationPolicy, Rule).
+var istioConfigGroupVersion = schema.GroupVersion{
+	Group:   "config.istio.io",
+	Version: "v1alpha2",
+}
+
+// istioNetworkingGroupVersion is the group/version the legacy istioNetworkingApi
+// RESTClient talks to, for the networking.istio.io CRDs (VirtualService, DestinationRule).
+var istioNetworkingGroupVersion = schema.GroupVersion{
+	Group:   "networking.istio.io",
+	Version: "v1alpha3",
+}
+
+// istioKnownTypes registers every Istio CRD kind the legacy REST clients need the
+// runtime.Scheme to know about, so NewClientFromConfig's hand-rolled scheme builder
+// doesn't need a type switch per kind. All Istio CRDs share the same generic Go
+// representation (GenericIstioObject), since their spec is free-form CRD JSON Kiali
+// only ever needs to read/write by field name, never by a kind-specific struct.
+var istioKnownTypes = map[string]struct {
+	object       runtime.Object
+	collection   runtime.Object
+	groupVersion *schema.GroupVersion
+}{
+	"routerule":          {&GenericIstioObject{}, &GenericIstioObjectList{}, &istioConfigGroupVersion},
+	"destinationpolicy":  {&GenericIstioObject{}, &GenericIstioObjectList{}, &istioConfigGroupVersion},
+	"rule":               {&GenericIstioObject{}, &GenericIstioObjectList{}, &istioConfigGroupVersion},
+	"virtualservice":     {&GenericIstioObject{}, &GenericIstioObjectList{}, &istioNetworkingGroupVersion},
+	"destinationrule":    {&GenericIstioObject{}, &GenericIstioObjectList{}, &istioNetworkingGroupVersion},
+}
+
+// istioKindForObjectType maps the lower-case plural resource name used in URLs/params
+// (as checkObjectType validates) to the singular istioKnownTypes key above.
+var istioKindForObjectType = map[string]string{
+	"routerules":          "routerule",
+	"destinationpolicies": "destinationpolicy",
+	"rules":               "rule",
+	"virtualservices":     "virtualservice",
+	"destinationrules":    "destinationrule",
+}
+
+// IstioObject is the common shape every Istio config object (RouteRule,
+// DestinationPolicy, VirtualService, DestinationRule, Rule) is read and written
+// through. Istio CRDs carry free-form spec JSON rather than a fixed Go struct per
+// kind, so callers read/write it as a map keyed by field name instead of through
+// generated accessors.
+type IstioObject interface {
+	runtime.Object
+	GetSpec() map[string]interface{}
+	SetSpec(spec map[string]interface{})
+	GetObjectMeta() meta_v1.ObjectMeta
+	SetObjectMeta(meta meta_v1.ObjectMeta)
+	DeepCopyIstioObject() IstioObject
+}
+
+// IstioObjectList is the list counterpart of IstioObject, returned by the Istio CRD
+// RESTClients' List calls.
+type IstioObjectList interface {
+	runtime.Object
+	GetItems() []IstioObject
+}
+
+// GenericIstioObject is the concrete IstioObject every Istio CRD kind decodes into.
+// Since Kiali only ever needs to read/write spec fields by name (see the envoy
+// package's *Spec helpers), there is no need for a distinct Go type per kind.
+type GenericIstioObject struct {
+	meta_v1.TypeMeta   `json:",inline"`
+	meta_v1.ObjectMeta `json:"metadata,omitempty"`
+	Spec               map[string]interface{} `json:"spec"`
+}
+
+// GetSpec returns the object's free-form spec fields.
+func (in *GenericIstioObject) GetSpec() map[string]interface{} {
+	return in.Spec
+}
+
+// SetSpec replaces the object's free-form spec fields.
+func (in *GenericIstioObject) SetSpec(spec map[string]interface{}) {
+	in.Spec = spec
+}
+
+// GetObjectMeta returns the object's Kubernetes metadata.
+func (in *GenericIstioObject) GetObjectMeta() meta_v1.ObjectMeta {
+	return in.ObjectMeta
+}
+
+// SetObjectMeta replaces the object's Kubernetes metadata.
+func (in *GenericIstioObject) SetObjectMeta(meta meta_v1.ObjectMeta) {
+	in.ObjectMeta = meta
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *GenericIstioObject) DeepCopyObject() runtime.Object {
+	return in.DeepCopyIstioObject()
+}
+
+// DeepCopyIstioObject returns a deep copy of in as an IstioObject.
+func (in *GenericIstioObject) DeepCopyIstioObject() IstioObject {
+	if in == nil {
+		return nil
+	}
+	out := &GenericIstioObject{
+		TypeMeta:   in.TypeMeta,
+		ObjectMeta: *in.ObjectMeta.DeepCopy(),
+	}
+	if in.Spec != nil {
+		out.Spec = runtime.DeepCopyJSON(in.Spec)
+	}
+	return out
+}
+
+// GenericIstioObjectList is the list counterpart of GenericIstioObject.
+type GenericIstioObjectList struct {
+	meta_v1.TypeMeta `json:",inline"`
+	meta_v1.ListMeta `json:"metadata,omitempty"`
+	Items            []GenericIstioObject `json:"items"`
+}
+
+// DeepCopyObject implements runtime.Object.
+func (in *GenericIstioObjectList) DeepCopyObject() runtime.Object {
+	if in == nil {
+		return nil
+	}
+	out := &GenericIstioObjectList{TypeMeta: in.TypeMeta, ListMeta: in.ListMeta}
+	out.Items = make([]GenericIstioObject, len(in.Items))
+	for i := range in.Items {
+		out.Items[i] = *in.Items[i].DeepCopyIstioObject().(*GenericIstioObject)
+	}
+	return out
+}
+
+// GetItems implements IstioObjectList.
+func (in *GenericIstioObjectList) GetItems() []IstioObject {
+	items := make([]IstioObject, len(in.Items))
+	for i := range in.Items {
+		items[i] = &in.Items[i]
+	}
+	return items
+}
+
+// IstioDetails bundles every Istio config object that targets a given service,
+// exactly as GetIstioDetails finds them, so callers like the envoy package's
+// BuildReport can correlate a pod's live Envoy config against what's registered for
+// its service without four separate round trips.
+type IstioDetails struct {
+	RouteRules          []IstioObject
+	DestinationPolicies []IstioObject
+	VirtualServices     []IstioObject
+	DestinationRules    []IstioObject
+}
+
+// ServiceDetails bundles a Service with the additional objects Kiali's service detail
+// views need alongside it.
+type ServiceDetails struct {
+	Service     *v1.Service
+	Endpoints   *v1.Endpoints
+	Deployments *[]IstioObject
+}
+
+// ServiceList is the result of GetServices: every Service in a namespace, plus the
+// Pods backing them, so callers can derive versions/labels without a second fetch.
+type ServiceList struct {
+	Services *v1.ServiceList
+	Pods     *v1.PodList
+}
+
+// IstioRules is the result of GetIstioRules: every Istio mixer Rule in a namespace.
+type IstioRules struct {
+	Rules []IstioObject
+}
+
+// IstioRuleDetails is the result of GetIstioRuleDetails: one Istio mixer Rule plus the
+// actions/handlers/instances it references.
+type IstioRuleDetails struct {
+	Rule    IstioObject
+	Actions []IstioObject
+}
@@ -48,30 +48,124 @@ func NewRoutes() (r *Routes) {
 			"/api/status",
 			handlers.Root,
 		},
+		{
+			"ClustersList",
+			"GET",
+			"/api/clusters",
+			handlers.ClustersList,
+		},
 		{
 			"IstioConfigList",
 			"GET",
 			"/api/namespaces/{namespace}/istio",
 			handlers.IstioConfigList,
 		},
+		{
+			// Alias of IstioConfigList that targets a specific remote cluster instead
+			// of the local one Kiali is deployed in.
+			"IstioConfigListForCluster",
+			"GET",
+			"/api/clusters/{cluster}/namespaces/{namespace}/istio",
+			handlers.IstioConfigList,
+		},
 		{
 			"IstioConfigDetails",
 			"GET",
 			"/api/namespaces/{namespace}/istio/{object_type}/{object}",
 			handlers.IstioConfigDetails,
 		},
+		{
+			// Alias of IstioConfigDetails that targets a specific remote cluster instead
+			// of the local one Kiali is deployed in.
+			"IstioConfigDetailsForCluster",
+			"GET",
+			"/api/clusters/{cluster}/namespaces/{namespace}/istio/{object_type}/{object}",
+			handlers.IstioConfigDetails,
+		},
+		{
+			"IstioConfigCreate",
+			"POST",
+			"/api/namespaces/{namespace}/istio/{object_type}",
+			handlers.CreateIstioConfig,
+		},
+		{
+			// Alias of IstioConfigCreate that targets a specific remote cluster instead
+			// of the local one Kiali is deployed in.
+			"IstioConfigCreateForCluster",
+			"POST",
+			"/api/clusters/{cluster}/namespaces/{namespace}/istio/{object_type}",
+			handlers.CreateIstioConfig,
+		},
+		{
+			"IstioConfigUpdate",
+			"PUT",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}",
+			handlers.UpdateIstioConfig,
+		},
+		{
+			// Alias of IstioConfigUpdate that targets a specific remote cluster instead
+			// of the local one Kiali is deployed in.
+			"IstioConfigUpdateForCluster",
+			"PUT",
+			"/api/clusters/{cluster}/namespaces/{namespace}/istio/{object_type}/{object}",
+			handlers.UpdateIstioConfig,
+		},
+		{
+			"IstioConfigDelete",
+			"DELETE",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}",
+			handlers.DeleteIstioConfig,
+		},
+		{
+			// Alias of IstioConfigDelete that targets a specific remote cluster instead
+			// of the local one Kiali is deployed in.
+			"IstioConfigDeleteForCluster",
+			"DELETE",
+			"/api/clusters/{cluster}/namespaces/{namespace}/istio/{object_type}/{object}",
+			handlers.DeleteIstioConfig,
+		},
 		{
 			"IstioConfigValidation",
 			"GET",
 			"/api/namespaces/{namespace}/istio/{object_type}/{object}/istio_validations",
 			handlers.IstioConfigValidations,
 		},
+		{
+			// Alias of IstioConfigValidation that targets a specific remote cluster
+			// instead of the local one Kiali is deployed in.
+			"IstioConfigValidationForCluster",
+			"GET",
+			"/api/clusters/{cluster}/namespaces/{namespace}/istio/{object_type}/{object}/istio_validations",
+			handlers.IstioConfigValidations,
+		},
+		{
+			"IstioConfigApply",
+			"POST",
+			"/api/namespaces/{namespace}/istio/{object_type}/{object}/apply",
+			handlers.ApplyIstioConfig,
+		},
+		{
+			// Alias of IstioConfigApply that targets a specific remote cluster instead
+			// of the local one Kiali is deployed in.
+			"IstioConfigApplyForCluster",
+			"POST",
+			"/api/clusters/{cluster}/namespaces/{namespace}/istio/{object_type}/{object}/apply",
+			handlers.ApplyIstioConfig,
+		},
 		{
 			"ServiceList",
 			"GET",
 			"/api/namespaces/{namespace}/services",
 			handlers.ServiceList,
 		},
+		{
+			// Alias of ServiceList that targets a specific remote cluster instead of
+			// the local one Kiali is deployed in.
+			"ServiceListForCluster",
+			"GET",
+			"/api/clusters/{cluster}/namespaces/{namespace}/services",
+			handlers.ServiceList,
+		},
 		{
 			"ServiceDetails",
 			"GET",
@@ -106,12 +200,34 @@ func NewRoutes() (r *Routes) {
 			"/api/namespaces/{namespace}/services/{service}/health",
 			handlers.ServiceHealth,
 		},
+		{
+			// Alias of ServiceHealth that targets a specific remote cluster instead of
+			// the local one Kiali is deployed in.
+			"ServiceHealthForCluster",
+			"GET",
+			"/api/clusters/{cluster}/namespaces/{namespace}/services/{service}/health",
+			handlers.ServiceHealth,
+		},
 		{
 			"ServiceValidations",
 			"GET",
 			"/api/namespaces/{namespace}/services/{service}/istio_validations",
 			handlers.ServiceIstioValidations,
 		},
+		{
+			"ServiceDescribe",
+			"GET",
+			"/api/namespaces/{namespace}/services/{service}/describe",
+			handlers.ServiceDescribe,
+		},
+		{
+			// Alias of ServiceDescribe that targets a specific remote cluster instead of
+			// the local one Kiali is deployed in.
+			"ServiceDescribeForCluster",
+			"GET",
+			"/api/clusters/{cluster}/namespaces/{namespace}/services/{service}/describe",
+			handlers.ServiceDescribe,
+		},
 		{
 			"NamespaceMetrics",
 			"GET",
@@ -139,6 +255,14 @@ func NewRoutes() (r *Routes) {
 			"/api/namespaces/{namespace}/graph",
 			handlers.GraphNamespace,
 		},
+		{
+			// Alias of GraphNamespace that targets a specific remote cluster instead
+			// of the local one Kiali is deployed in.
+			"GraphNamespaceForCluster",
+			"GET",
+			"/api/clusters/{cluster}/namespaces/{namespace}/graph",
+			handlers.GraphNamespace,
+		},
 		{
 			// Supported query parameters:
 			// metric:         Prometheus metric name used to generate the dependency graph (default=istio_request_count)
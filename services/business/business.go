@@ -0,0 +1,68 @@
+// Package business is the per-request facade handlers go through to reach Istio
+// config and validation logic, instead of constructing a kubernetes.IstioClient
+// themselves. It is the one place that resolves a `cluster` selector (LocalCluster or
+// a name registered by kubernetes.ClusterSecretWatcher) to the IstioClient that talks
+// to it.
+package business
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// Layer bundles the IstioClient for one cluster with the services built on top of it.
+// Handlers ask for one via Get and use it for the rest of the request.
+type Layer struct {
+	// Client is the raw IstioClient for the resolved cluster, for handlers (service
+	// describe, apply, Istio config CRUD) that need kubernetes/envoy/statuscheck APIs
+	// IstioConfig/Validations don't wrap.
+	Client      *kubernetes.IstioClient
+	IstioConfig *IstioConfigService
+	Validations *ValidationsService
+}
+
+// Get builds a Layer wired to the named cluster's IstioClient. cluster is normally
+// kubernetes.LocalCluster, or a name previously registered in kubernetes.Clusters by
+// the ClusterSecretWatcher for a multi-cluster request.
+func Get(cluster string) (*Layer, error) {
+	client, err := getClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Layer{
+		Client:      client,
+		IstioConfig: &IstioConfigService{k8s: client},
+		Validations: &ValidationsService{k8s: client},
+	}, nil
+}
+
+// localClientOnce/localClient/localClientErr cache the in-cluster IstioClient across
+// every Get("") / Get(LocalCluster) call, the same way ClusterRegistry caches one
+// IstioClient per remote cluster instead of rebuilding it per request.
+var (
+	localClientOnce sync.Once
+	localClient     *kubernetes.IstioClient
+	localClientErr  error
+)
+
+// getClient resolves cluster to an IstioClient: LocalCluster (or the empty string,
+// for routes that were never given a `cluster` selector at all) builds the in-cluster
+// client once and reuses it, while any other name is looked up in the registry the
+// ClusterSecretWatcher maintains.
+func getClient(cluster string) (*kubernetes.IstioClient, error) {
+	if cluster == kubernetes.LocalCluster || cluster == "" {
+		localClientOnce.Do(func() {
+			localClient, localClientErr = kubernetes.NewClient()
+		})
+		return localClient, localClientErr
+	}
+
+	info, ok := kubernetes.Clusters.Get(cluster)
+	if !ok {
+		return nil, fmt.Errorf("unknown cluster: %s", cluster)
+	}
+	return info.Client, nil
+}
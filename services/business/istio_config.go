@@ -0,0 +1,101 @@
+package business
+
+import (
+	"fmt"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+// IstioConfigCriteria narrows GetIstioConfig/SwitchRoute to the namespace and Istio
+// config kinds the caller actually wants, so a request for one kind doesn't pay for
+// round trips to the other four.
+type IstioConfigCriteria struct {
+	Namespace                  string
+	IncludeRouteRules          bool
+	IncludeDestinationPolicies bool
+	IncludeVirtualServices     bool
+	IncludeDestinationRules    bool
+	IncludeRules               bool
+}
+
+// IstioConfigList is the aggregated result of GetIstioConfig/SwitchRoute: every kind
+// of Istio config object Criteria asked for, in the namespace it named.
+type IstioConfigList struct {
+	Namespace           string                   `json:"namespace"`
+	RouteRules          []kubernetes.IstioObject `json:"routeRules,omitempty"`
+	DestinationPolicies []kubernetes.IstioObject `json:"destinationPolicies,omitempty"`
+	VirtualServices     []kubernetes.IstioObject `json:"virtualServices,omitempty"`
+	DestinationRules    []kubernetes.IstioObject `json:"destinationRules,omitempty"`
+	Rules               []kubernetes.IstioObject `json:"rules,omitempty"`
+}
+
+// IstioConfigService is the business-layer view of Istio config objects for one
+// cluster: it aggregates kubernetes.IstioClient's per-kind Get calls behind the
+// namespace/criteria-shaped API handlers work with.
+type IstioConfigService struct {
+	k8s *kubernetes.IstioClient
+}
+
+// SwitchRoute backs the legacy /api/namespaces/{namespace}/switch test endpoint. It
+// reports the same aggregated view GetIstioConfig does.
+func (s *IstioConfigService) SwitchRoute(criteria IstioConfigCriteria) (*IstioConfigList, error) {
+	return s.GetIstioConfig(criteria)
+}
+
+// GetIstioConfig aggregates every Istio config kind criteria asked for.
+func (s *IstioConfigService) GetIstioConfig(criteria IstioConfigCriteria) (*IstioConfigList, error) {
+	list := &IstioConfigList{Namespace: criteria.Namespace}
+
+	var err error
+	if criteria.IncludeRouteRules {
+		if list.RouteRules, err = s.k8s.GetRouteRules(criteria.Namespace, ""); err != nil {
+			return nil, err
+		}
+	}
+	if criteria.IncludeDestinationPolicies {
+		if list.DestinationPolicies, err = s.k8s.GetDestinationPolicies(criteria.Namespace, ""); err != nil {
+			return nil, err
+		}
+	}
+	if criteria.IncludeVirtualServices {
+		if list.VirtualServices, err = s.k8s.GetVirtualServices(criteria.Namespace, ""); err != nil {
+			return nil, err
+		}
+	}
+	if criteria.IncludeDestinationRules {
+		if list.DestinationRules, err = s.k8s.GetDestinationRules(criteria.Namespace, ""); err != nil {
+			return nil, err
+		}
+	}
+	if criteria.IncludeRules {
+		rules, err := s.k8s.GetIstioRules(criteria.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		list.Rules = rules.Rules
+	}
+
+	return list, nil
+}
+
+// GetIstioConfigDetails fetches the single Istio config object named object, of kind
+// objectType, in namespace. "rules" returns a *kubernetes.IstioRuleDetails rather than
+// a bare kubernetes.IstioObject, since a mixer Rule is reported together with the
+// actions it references; callers that need a plain IstioObject (validation, apply)
+// should type-assert accordingly.
+func (s *IstioConfigService) GetIstioConfigDetails(namespace string, objectType string, object string) (interface{}, error) {
+	switch objectType {
+	case "routerules":
+		return s.k8s.GetRouteRule(namespace, object)
+	case "destinationpolicies":
+		return s.k8s.GetDestinationPolicy(namespace, object)
+	case "virtualservices":
+		return s.k8s.GetVirtualService(namespace, object)
+	case "destinationrules":
+		return s.k8s.GetDestinationRule(namespace, object)
+	case "rules":
+		return s.k8s.GetIstioRuleDetails(namespace, object)
+	default:
+		return nil, fmt.Errorf("unknown Istio object type: %s", objectType)
+	}
+}
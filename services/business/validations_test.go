@@ -0,0 +1,43 @@
+package business
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/kiali/kiali/kubernetes"
+)
+
+func TestValidateIstioObjectRejectsMissingName(t *testing.T) {
+	assert := assert.New(t)
+
+	object := &kubernetes.GenericIstioObject{Spec: map[string]interface{}{"hosts": []interface{}{"reviews"}}}
+	validations := validateIstioObject(object)
+
+	assert.False(validations.Valid)
+	assert.Contains(validations.Checks, "metadata.name is required")
+}
+
+func TestValidateIstioObjectRejectsEmptySpec(t *testing.T) {
+	assert := assert.New(t)
+
+	object := &kubernetes.GenericIstioObject{ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-routing"}}
+	validations := validateIstioObject(object)
+
+	assert.False(validations.Valid)
+	assert.Contains(validations.Checks, "spec must not be empty")
+}
+
+func TestValidateIstioObjectAcceptsWellFormedObject(t *testing.T) {
+	assert := assert.New(t)
+
+	object := &kubernetes.GenericIstioObject{
+		ObjectMeta: meta_v1.ObjectMeta{Name: "reviews-routing"},
+		Spec:       map[string]interface{}{"hosts": []interface{}{"reviews"}},
+	}
+	validations := validateIstioObject(object)
+
+	assert.True(validations.Valid)
+	assert.Empty(validations.Checks)
+}